@@ -0,0 +1,119 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// nsKinds are the /proc/<pid>/ns entries we fingerprint, in the order their
+// inode values are read.
+var nsKinds = []string{"user", "pid", "mnt", "net"}
+
+// hostNSInodes caches PID 1's namespace inodes, which never change over a
+// scan, so readNamespaces doesn't re-read them for every process.
+var (
+	hostNSOnce   sync.Once
+	hostNSInodes map[string]uint64
+)
+
+func hostNamespaces() map[string]uint64 {
+	hostNSOnce.Do(func() {
+		hostNSInodes = nsInodes(1)
+	})
+	return hostNSInodes
+}
+
+// readNamespaces fingerprints pid's namespaces and compares its user
+// namespace against PID 1's to decide whether pid runs in a non-host user
+// namespace, as rootless containers do (their cgroup alone doesn't reveal
+// them). The PID, mount, and network namespaces are recorded but not
+// compared against PID 1's.
+func readNamespaces(pid int) model.Namespaces {
+	inodes := nsInodes(pid)
+	hostInodes := hostNamespaces()
+
+	ns := model.Namespaces{
+		UserNS: inodes["user"],
+		PIDNS:  inodes["pid"],
+		MntNS:  inodes["mnt"],
+		NetNS:  inodes["net"],
+	}
+	ns.Rootless = ns.UserNS != 0 && hostInodes["user"] != 0 && ns.UserNS != hostInodes["user"]
+	ns.HostUID = hostUID(pid, ns.Rootless)
+	return ns
+}
+
+// nsInodes reads the inode number out of each /proc/<pid>/ns/<kind> symlink
+// target, which has the form "<kind>:[<inode>]".
+func nsInodes(pid int) map[string]uint64 {
+	inodes := make(map[string]uint64, len(nsKinds))
+	for _, kind := range nsKinds {
+		target, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/%s", pid, kind))
+		if err != nil {
+			continue
+		}
+		open := strings.Index(target, "[")
+		close := strings.Index(target, "]")
+		if open == -1 || close == -1 || close < open {
+			continue
+		}
+		if inode, err := strconv.ParseUint(target[open+1:close], 10, 64); err == nil {
+			inodes[kind] = inode
+		}
+	}
+	return inodes
+}
+
+// hostUID recovers the host-owned UID a process's user namespace belongs
+// to. For a host-namespace process this is just its own UID; for a
+// namespaced one it's the outside-id that uid_map maps in-container UID 0
+// to, i.e. the user on the host who created the namespace.
+func hostUID(pid int, rootless bool) int {
+	if !rootless {
+		return ownUID(pid)
+	}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/uid_map", pid))
+	if err != nil {
+		return ownUID(pid)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		if insideID, err := strconv.Atoi(fields[0]); err == nil && insideID == 0 {
+			if outsideID, err := strconv.Atoi(fields[1]); err == nil {
+				return outsideID
+			}
+		}
+	}
+	return ownUID(pid)
+}
+
+// ownUID reads a process's own UID from /proc/<pid>/status, used as
+// the fallback HostUID for host-namespace processes and when uid_map can't
+// be parsed.
+func ownUID(pid int) int {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return -1
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "Uid:") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 {
+				if uid, err := strconv.Atoi(fields[1]); err == nil {
+					return uid
+				}
+			}
+		}
+	}
+	return -1
+}
@@ -3,6 +3,7 @@
 package proc
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,10 +11,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pranshuparmar/witr/pkg/docker"
+	"github.com/pranshuparmar/witr/pkg/kubepods"
 	"github.com/pranshuparmar/witr/pkg/model"
+	containerruntime "github.com/pranshuparmar/witr/pkg/runtime"
 )
 
-func ReadProcess(pid int) (model.Process, error) {
+// ReadProcess inspects /proc for pid and returns everything witr knows about
+// it. dockerResolver resolves a docker-proxy target IP to its container,
+// runtimeResolver resolves a cgroup-derived container ID to its name and
+// image for containerd/CRI-O/Podman, and podResolver resolves a kubepods
+// pod UID to its pod name, namespace, and container name. All three are
+// created once per scan so tests can stub them out; any may be nil, in
+// which case the corresponding metadata is simply left unpopulated.
+func ReadProcess(pid int, dockerResolver docker.Resolver, runtimeResolver containerruntime.Resolver, podResolver kubepods.Resolver) (model.Process, error) {
 	// Read environment variables
 	env := []string{}
 	envBytes, errEnv := os.ReadFile(fmt.Sprintf("/proc/%d/environ", pid))
@@ -24,7 +35,8 @@ func ReadProcess(pid int) (model.Process, error) {
 			}
 		}
 	}
-	// Health status
+	// Host health status (HostHealth below, not the container runtime's
+	// own opinion)
 	health := "healthy"
 	forked := "unknown"
 
@@ -34,17 +46,70 @@ func ReadProcess(pid int) (model.Process, error) {
 		cwd = "unknown"
 	}
 
-	// Container detection (simple: look for docker/containerd/kubepods in cgroup)
+	// Container detection: parse the cgroup path (v1 or v2) to identify
+	// Docker, containerd, CRI-O, and Podman containers, including rootless
+	// Podman/Docker under a user.slice.
 	container := ""
-	cgroupFile := fmt.Sprintf("/proc/%d/cgroup", pid)
-	if cgroupData, err := os.ReadFile(cgroupFile); err == nil {
-		cgroupStr := string(cgroupData)
-		if strings.Contains(cgroupStr, "docker") {
-			container = "docker"
-		} else if strings.Contains(cgroupStr, "containerd") {
-			container = "containerd"
-		} else if strings.Contains(cgroupStr, "kubepods") {
-			container = "kubernetes"
+	var containerID, containerRuntime, containerImage string
+	var podName, podNamespace, podUID, k8sContainerName, qosClass string
+	var containerHealth model.ContainerHealth
+	if cgroupPaths, err := containerruntime.CgroupPaths(pid); err == nil {
+		for _, path := range cgroupPaths {
+			rt := containerruntime.ParsePath(path)
+			if rt.Runtime == "" {
+				continue
+			}
+			container = rt.Runtime
+			containerID = rt.ContainerID
+			containerRuntime = rt.Runtime
+			switch {
+			case containerID != "" && rt.Runtime == containerruntime.Docker && dockerResolver != nil:
+				// Docker containers are resolved via pkg/docker, not
+				// pkg/runtime, since that's the client with the Engine API
+				// socket.
+				if c, err := dockerResolver.ResolveByID(containerID); err == nil {
+					if c.Name != "" {
+						container = "target: " + c.Name
+					}
+					containerImage = c.Image
+					containerHealth = model.ContainerHealth{
+						Status:        c.Health.Status,
+						FailingStreak: c.Health.FailingStreak,
+						RestartCount:  c.RestartCount,
+						LastProbeAt:   c.Health.LastProbeAt,
+						LastExitCode:  c.Health.LastExitCode,
+					}
+				}
+			case containerID != "" && runtimeResolver != nil:
+				if c, err := runtimeResolver.Resolve(rt); err == nil {
+					if c.Name != "" {
+						container = "target: " + c.Name
+					}
+					containerImage = c.Image
+					containerHealth = model.ContainerHealth{
+						Status:        c.Health.Status,
+						FailingStreak: c.Health.FailingStreak,
+						RestartCount:  c.RestartCount,
+						LastProbeAt:   c.Health.LastProbeAt,
+						LastExitCode:  c.Health.LastExitCode,
+					}
+				}
+			}
+			if rt.Runtime == "kubernetes" {
+				if kp, ok := kubepods.Parse(path); ok {
+					podUID = kp.PodUID
+					qosClass = kp.QoSClass
+					containerID = kp.ContainerID
+					if podResolver != nil {
+						if meta, err := podResolver.Resolve(kp); err == nil {
+							podName = meta.PodName
+							podNamespace = meta.PodNamespace
+							k8sContainerName = meta.ContainerName
+						}
+					}
+				}
+			}
+			break
 		}
 	}
 
@@ -153,6 +218,7 @@ func ReadProcess(pid int) (model.Process, error) {
 	}
 
 	user := readUser(pid)
+	namespaces := readNamespaces(pid)
 
 	sockets, _ := readListeningSockets()
 	inodes := socketsForPID(pid)
@@ -175,33 +241,61 @@ func ReadProcess(pid int) (model.Process, error) {
 	}
 
 	// Docker-proxy resolution: resolve target container IP to container name
+	var imageName, composeProject string
+	var containerLabels map[string]string
 	if comm == "docker-proxy" && container == "" {
-		container = resolveDockerProxyContainer(cmdline)
+		var c docker.Container
+		container, c = resolveDockerProxyContainer(cmdline, dockerResolver)
+		imageName = c.Image
+		composeProject = c.ComposeProject
+		containerLabels = c.Labels
+		containerHealth = model.ContainerHealth{
+			Status:        c.Health.Status,
+			FailingStreak: c.Health.FailingStreak,
+			RestartCount:  c.RestartCount,
+			LastProbeAt:   c.Health.LastProbeAt,
+			LastExitCode:  c.Health.LastExitCode,
+		}
 	}
 
 	return model.Process{
-		PID:            pid,
-		PPID:           ppid,
-		Command:        comm,
-		Cmdline:        cmdline,
-		StartedAt:      startedAt,
-		User:           user,
-		WorkingDir:     cwd,
-		GitRepo:        gitRepo,
-		GitBranch:      gitBranch,
-		Container:      container,
-		Service:        service,
-		ListeningPorts: ports,
-		BindAddresses:  addrs,
-		Health:         health,
-		Forked:         forked,
-		Env:            env,
+		PID:             pid,
+		PPID:            ppid,
+		Command:         comm,
+		Cmdline:         cmdline,
+		StartedAt:       startedAt,
+		User:            user,
+		WorkingDir:      cwd,
+		GitRepo:         gitRepo,
+		GitBranch:       gitBranch,
+		Container:       container,
+		Service:         service,
+		ListeningPorts:  ports,
+		BindAddresses:   addrs,
+		HostHealth:      health,
+		Forked:          forked,
+		Env:             env,
+		ImageName:       imageName,
+		ComposeProject:  composeProject,
+		ContainerLabels: containerLabels,
+		ContainerHealth: containerHealth,
+		ContainerID:     containerID,
+		Runtime:         containerRuntime,
+		Image:           containerImage,
+		PodName:         podName,
+		PodNamespace:    podNamespace,
+		PodUID:          podUID,
+		ContainerName:   k8sContainerName,
+		QoSClass:        qosClass,
+		Namespaces:      namespaces,
 	}, nil
 }
 
-// resolveDockerProxyContainer extracts the container IP from docker-proxy cmdline
-// and queries Docker to find the container name for that IP.
-func resolveDockerProxyContainer(cmdline string) string {
+// resolveDockerProxyContainer extracts the container IP from docker-proxy's
+// cmdline and resolves it to a container via resolver, the Docker Engine
+// API over /var/run/docker.sock. If resolver is nil or the socket is
+// unavailable, it falls back to shelling out to `docker network inspect`.
+func resolveDockerProxyContainer(cmdline string, resolver docker.Resolver) (string, docker.Container) {
 	// Parse -container-ip argument from cmdline
 	var containerIP string
 	parts := strings.Fields(cmdline)
@@ -213,15 +307,28 @@ func resolveDockerProxyContainer(cmdline string) string {
 	}
 
 	if containerIP == "" {
-		return ""
+		return "", docker.Container{}
+	}
+
+	if resolver != nil {
+		c, err := resolver.ResolveByIP(containerIP)
+		switch {
+		case err == nil:
+			return "target: " + c.Name, c
+		case errors.Is(err, docker.ErrNotFound):
+			// The daemon was reached fine; it just has no container at
+			// that IP. The CLI path would find nothing either, so don't
+			// bother shelling out.
+			return "", docker.Container{}
+		}
 	}
 
-	// Query Docker to find container with this IP
-	// Use docker network inspect to get container names and IPs
+	// Fall back to the docker CLI path, used when the socket is unavailable
+	// (e.g. the caller isn't in the docker group).
 	out, err := exec.Command("docker", "network", "inspect", "bridge",
 		"--format", "{{range .Containers}}{{.Name}}:{{.IPv4Address}}{{\"\\n\"}}{{end}}").Output()
 	if err != nil {
-		return ""
+		return "", docker.Container{}
 	}
 
 	// Parse output to find matching container
@@ -240,9 +347,9 @@ func resolveDockerProxyContainer(cmdline string) string {
 		// Strip CIDR notation (e.g., /16)
 		ip := strings.Split(ipWithCIDR, "/")[0]
 		if ip == containerIP {
-			return "target: " + name
+			return "target: " + name, docker.Container{Name: name}
 		}
 	}
 
-	return ""
+	return "", docker.Container{}
 }
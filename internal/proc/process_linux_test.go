@@ -0,0 +1,65 @@
+//go:build linux
+
+package proc
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/docker"
+)
+
+// fakeDockerResolver is a docker.Resolver stub so resolveDockerProxyContainer
+// can be tested without a real daemon socket. byIP matches are returned
+// as-is; an unmatched IP returns a fakeNotFoundErr, wrapping
+// docker.ErrNotFound just like the real Client does for a reachable daemon
+// with no match. ResolveByID always returns docker.ErrNotFound.
+type fakeDockerResolver struct {
+	byIP map[string]docker.Container
+}
+
+func (f *fakeDockerResolver) ResolveByIP(ip string) (docker.Container, error) {
+	if c, ok := f.byIP[ip]; ok {
+		return c, nil
+	}
+	return docker.Container{}, fakeNotFoundErr{ip}
+}
+
+func (f *fakeDockerResolver) ResolveByID(id string) (docker.Container, error) {
+	return docker.Container{}, docker.ErrNotFound
+}
+
+type fakeNotFoundErr struct{ ip string }
+
+func (e fakeNotFoundErr) Error() string { return "docker: no matching container: IP " + e.ip }
+func (e fakeNotFoundErr) Unwrap() error { return docker.ErrNotFound }
+
+func TestResolveDockerProxyContainerFound(t *testing.T) {
+	resolver := &fakeDockerResolver{byIP: map[string]docker.Container{
+		"172.17.0.2": {Name: "web", Image: "nginx:latest"},
+	}}
+
+	name, c := resolveDockerProxyContainer("docker-proxy -container-ip 172.17.0.2 -container-port 80", resolver)
+
+	if name != "target: web" || c.Image != "nginx:latest" {
+		t.Fatalf("resolveDockerProxyContainer = (%q, %+v)", name, c)
+	}
+}
+
+func TestResolveDockerProxyContainerNotFoundSkipsCLIFallback(t *testing.T) {
+	// The daemon is reachable but has no container at this IP: the CLI
+	// fallback must not run, since it would find nothing either.
+	resolver := &fakeDockerResolver{}
+
+	name, c := resolveDockerProxyContainer("docker-proxy -container-ip 10.0.0.9", resolver)
+
+	if name != "" || c != (docker.Container{}) {
+		t.Fatalf("resolveDockerProxyContainer = (%q, %+v), want empty", name, c)
+	}
+}
+
+func TestResolveDockerProxyContainerNoContainerIP(t *testing.T) {
+	name, c := resolveDockerProxyContainer("docker-proxy -proto tcp", &fakeDockerResolver{})
+	if name != "" || c != (docker.Container{}) {
+		t.Fatalf("resolveDockerProxyContainer = (%q, %+v), want empty", name, c)
+	}
+}
@@ -0,0 +1,201 @@
+// Package docker provides a minimal client for the Docker Engine API,
+// talking to the daemon over its Unix socket instead of shelling out to the
+// docker CLI.
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned by ResolveByIP/ResolveByID when the daemon was
+// reached fine but no container matched. Callers use this to distinguish
+// "the daemon says no" from "the daemon couldn't be reached at all".
+var ErrNotFound = errors.New("docker: no matching container")
+
+// Health is a container's Docker-reported healthcheck state, taken from
+// State.Health in the inspect payload.
+type Health struct {
+	// Status is "starting", "healthy", or "unhealthy".
+	Status        string
+	FailingStreak int
+	// LastProbeAt and LastExitCode describe the most recent healthcheck
+	// probe, taken from the last entry of State.Health.Log.
+	LastProbeAt  time.Time
+	LastExitCode int
+}
+
+const defaultSocket = "/var/run/docker.sock"
+
+// Container is the subset of a container's inspect payload that witr cares
+// about when attributing a process to it.
+type Container struct {
+	ID             string
+	Name           string
+	Image          string
+	Labels         map[string]string
+	ComposeProject string
+	Health         Health
+	RestartCount   int
+}
+
+// Resolver maps runtime-level identifiers (a container IP, a container ID)
+// to Container metadata. It is implemented by *Client, and stubbed out in
+// tests.
+type Resolver interface {
+	// ResolveByIP returns the container whose network settings report ip as
+	// one of its addresses. It returns an error if no such container exists.
+	ResolveByIP(ip string) (Container, error)
+	// ResolveByID returns the container with the given ID, as found in a
+	// process's cgroup path.
+	ResolveByID(id string) (Container, error)
+}
+
+// Client talks to a Docker Engine API socket.
+type Client struct {
+	http *http.Client
+}
+
+// NewClient creates a Client for the daemon at DOCKER_HOST, falling back to
+// the default Unix socket. It does not dial the daemon; call Available to
+// verify connectivity.
+func NewClient() *Client {
+	socket := defaultSocket
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		socket = strings.TrimPrefix(host, "unix://")
+	}
+	return &Client{
+		http: &http.Client{
+			Timeout: 2 * time.Second,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socket)
+				},
+			},
+		},
+	}
+}
+
+// Available reports whether the daemon socket can be dialed at all, so
+// callers can fall back to the docker CLI when it can't.
+func (c *Client) Available() bool {
+	resp, err := c.http.Get("http://docker/_ping")
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+type containerSummary struct {
+	Id    string
+	Names []string
+	Image string
+}
+
+type containerInspect struct {
+	Id     string
+	Name   string
+	Config struct {
+		Image  string
+		Labels map[string]string
+	}
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress string
+		}
+	}
+	State struct {
+		RestartCount int
+		Health       struct {
+			Status        string
+			FailingStreak int
+			Log           []struct {
+				Start    time.Time
+				ExitCode int
+			}
+		}
+	}
+}
+
+// ResolveByIP implements Resolver.
+func (c *Client) ResolveByIP(ip string) (Container, error) {
+	var summaries []containerSummary
+	if err := c.get("/containers/json?all=1", &summaries); err != nil {
+		return Container{}, err
+	}
+
+	for _, s := range summaries {
+		inspect, err := c.inspect(s.Id)
+		if err != nil {
+			continue
+		}
+		for _, net := range inspect.NetworkSettings.Networks {
+			if net.IPAddress == ip {
+				return toContainer(inspect), nil
+			}
+		}
+	}
+
+	return Container{}, fmt.Errorf("%w: IP %s", ErrNotFound, ip)
+}
+
+// ResolveByID implements Resolver, for containers found via their cgroup
+// path rather than a docker-proxy target IP.
+func (c *Client) ResolveByID(id string) (Container, error) {
+	inspect, err := c.inspect(id)
+	if err != nil {
+		return Container{}, err
+	}
+	return toContainer(inspect), nil
+}
+
+func (c *Client) inspect(id string) (containerInspect, error) {
+	var inspect containerInspect
+	err := c.get(fmt.Sprintf("/containers/%s/json", id), &inspect)
+	return inspect, err
+}
+
+func toContainer(inspect containerInspect) Container {
+	health := Health{
+		Status:        inspect.State.Health.Status,
+		FailingStreak: inspect.State.Health.FailingStreak,
+	}
+	if n := len(inspect.State.Health.Log); n > 0 {
+		last := inspect.State.Health.Log[n-1]
+		health.LastProbeAt = last.Start
+		health.LastExitCode = last.ExitCode
+	}
+	return Container{
+		ID:             inspect.Id,
+		Name:           strings.TrimPrefix(inspect.Name, "/"),
+		Image:          inspect.Config.Image,
+		Labels:         inspect.Config.Labels,
+		ComposeProject: inspect.Config.Labels["com.docker.compose.project"],
+		Health:         health,
+		RestartCount:   inspect.State.RestartCount,
+	}
+}
+
+func (c *Client) get(path string, out interface{}) error {
+	resp, err := c.http.Get("http://docker" + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("docker: %s: unexpected status %d", path, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,111 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// newTestClient starts an httptest server listening on a Unix socket under
+// dir and returns a Client wired up to talk to it, mirroring how NewClient
+// dials the real Docker Engine API socket.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := &httptest.Server{Listener: l, Config: &http.Server{Handler: handler}}
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	return &Client{
+		http: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socket)
+				},
+			},
+		},
+	}
+}
+
+func TestClientResolveByID(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/containers/abc123/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"Id": "abc123",
+			"Name": "/web",
+			"Config": {"Image": "nginx:latest", "Labels": {"com.docker.compose.project": "myapp"}},
+			"State": {"RestartCount": 2, "Health": {"Status": "healthy", "FailingStreak": 0}}
+		}`))
+	})
+	c := newTestClient(t, mux)
+
+	got, err := c.ResolveByID("abc123")
+	if err != nil {
+		t.Fatalf("ResolveByID: %v", err)
+	}
+	if got.Name != "web" || got.Image != "nginx:latest" || got.ComposeProject != "myapp" || got.RestartCount != 2 {
+		t.Fatalf("ResolveByID = %+v", got)
+	}
+}
+
+func TestClientResolveByIDNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/containers/missing/json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	c := newTestClient(t, mux)
+
+	_, err := c.ResolveByID("missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ResolveByID error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClientResolveByIP(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"Id": "abc123", "Names": ["/web"], "Image": "nginx:latest"}]`))
+	})
+	mux.HandleFunc("/containers/abc123/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"Id": "abc123",
+			"Name": "/web",
+			"Config": {"Image": "nginx:latest"},
+			"NetworkSettings": {"Networks": {"bridge": {"IPAddress": "172.17.0.2"}}}
+		}`))
+	})
+	c := newTestClient(t, mux)
+
+	got, err := c.ResolveByIP("172.17.0.2")
+	if err != nil {
+		t.Fatalf("ResolveByIP: %v", err)
+	}
+	if got.Name != "web" {
+		t.Fatalf("ResolveByIP = %+v", got)
+	}
+
+	if _, err := c.ResolveByIP("10.0.0.9"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ResolveByIP(unmatched) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClientAvailable(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+	c := newTestClient(t, mux)
+
+	if !c.Available() {
+		t.Fatal("Available() = false, want true")
+	}
+}
@@ -0,0 +1,68 @@
+// Package kubepods parses the pod UID and container ID out of a process's
+// kubepods cgroup path, and resolves them to pod/namespace/container
+// metadata.
+package kubepods
+
+import "regexp"
+
+// Info is what can be recovered from a kubepods cgroup path alone, with no
+// calls to the kubelet or a container runtime.
+type Info struct {
+	// QoSClass is "guaranteed", "burstable", or "besteffort", taken
+	// directly from the cgroup path.
+	QoSClass string
+	// PodUID is the pod's UID, with dashes restored (cgroup v2 path
+	// components use underscores in their place).
+	PodUID string
+	// ContainerID is the full container ID, if the path reaches all the
+	// way down to a specific container rather than stopping at the pod.
+	ContainerID string
+}
+
+// cgroup v2: kubepods.slice/kubepods-<qos>.slice/kubepods-<qos>-pod<uid>.slice/<runtime>-<cid>.scope
+// Guaranteed-QoS pods have no "<qos>" infix at all: kubepods.slice/kubepods-pod<uid>.slice/...
+var v2PodRE = regexp.MustCompile(`kubepods-(?:(burstable|besteffort)-)?pod([0-9a-f_]{36})\.slice`)
+var v2ContainerRE = regexp.MustCompile(`(?:cri-containerd|crio|docker)-([0-9a-f]{12,64})\.scope`)
+
+// cgroup v1: /kubepods/<qos>/pod<uid-with-dashes>/<cid>
+// Guaranteed-QoS pods again omit the "<qos>" segment: /kubepods/pod<uid>/<cid>
+var v1RE = regexp.MustCompile(`kubepods/(?:(burstable|besteffort)/)?pod([0-9a-f-]{36})/([0-9a-f]{12,64})?`)
+
+// Parse extracts Info from a single cgroup path. ok is false if path has no
+// kubepods pod component (including paths not under kubepods at all).
+func Parse(path string) (info Info, ok bool) {
+	if m := v1RE.FindStringSubmatch(path); m != nil {
+		return Info{QoSClass: qosClass(m[1]), PodUID: m[2], ContainerID: m[3]}, true
+	}
+	if m := v2PodRE.FindStringSubmatch(path); m != nil {
+		info = Info{QoSClass: qosClass(m[1]), PodUID: dashify(m[2])}
+		if c := v2ContainerRE.FindStringSubmatch(path); c != nil {
+			info.ContainerID = c[1]
+		}
+		return info, true
+	}
+	return Info{}, false
+}
+
+// qosClass defaults an empty QoS capture group to "guaranteed", the one
+// class with no infix in the cgroup path.
+func qosClass(captured string) string {
+	if captured == "" {
+		return "guaranteed"
+	}
+	return captured
+}
+
+// dashify turns the underscore-separated UID cgroup v2 uses
+// (d9ff0dc0_4843_4922_9f48_1fb51bb6b770) back into the canonical
+// dash-separated UUID form.
+func dashify(uid string) string {
+	out := []byte(uid)
+	// A UUID has dashes at fixed positions: 8-4-4-4-12.
+	for _, i := range []int{8, 13, 18, 23} {
+		if i < len(out) && out[i] == '_' {
+			out[i] = '-'
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,73 @@
+package kubepods
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		ok   bool
+		want Info
+	}{
+		{
+			name: "v2 burstable with container",
+			path: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod9a8f8e32_1c3b_4e1e_8a3e_7a4e2e1a2b3c.slice/cri-containerd-" + hex64 + ".scope",
+			ok:   true,
+			want: Info{QoSClass: "burstable", PodUID: "9a8f8e32-1c3b-4e1e-8a3e-7a4e2e1a2b3c", ContainerID: hex64},
+		},
+		{
+			name: "v2 guaranteed has no qos infix",
+			path: "/kubepods.slice/kubepods-pod9a8f8e32_1c3b_4e1e_8a3e_7a4e2e1a2b3c.slice/docker-" + hex64 + ".scope",
+			ok:   true,
+			want: Info{QoSClass: "guaranteed", PodUID: "9a8f8e32-1c3b-4e1e-8a3e-7a4e2e1a2b3c", ContainerID: hex64},
+		},
+		{
+			name: "v2 pod only, no container",
+			path: "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod9a8f8e32_1c3b_4e1e_8a3e_7a4e2e1a2b3c.slice",
+			ok:   true,
+			want: Info{QoSClass: "besteffort", PodUID: "9a8f8e32-1c3b-4e1e-8a3e-7a4e2e1a2b3c"},
+		},
+		{
+			name: "v1 burstable",
+			path: "/kubepods/burstable/pod9a8f8e32-1c3b-4e1e-8a3e-7a4e2e1a2b3c/" + hex64,
+			ok:   true,
+			want: Info{QoSClass: "burstable", PodUID: "9a8f8e32-1c3b-4e1e-8a3e-7a4e2e1a2b3c", ContainerID: hex64},
+		},
+		{
+			name: "v1 guaranteed has no qos infix",
+			path: "/kubepods/pod9a8f8e32-1c3b-4e1e-8a3e-7a4e2e1a2b3c/" + hex64,
+			ok:   true,
+			want: Info{QoSClass: "guaranteed", PodUID: "9a8f8e32-1c3b-4e1e-8a3e-7a4e2e1a2b3c", ContainerID: hex64},
+		},
+		{
+			name: "not kubepods at all",
+			path: "/system.slice/docker-" + hex64 + ".scope",
+			ok:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			info, ok := Parse(tc.path)
+			if ok != tc.ok {
+				t.Fatalf("ok = %v, want %v", ok, tc.ok)
+			}
+			if !ok {
+				return
+			}
+			if info != tc.want {
+				t.Fatalf("Parse(%q) = %+v, want %+v", tc.path, info, tc.want)
+			}
+		})
+	}
+}
+
+func TestDashify(t *testing.T) {
+	got := dashify("9a8f8e32_1c3b_4e1e_8a3e_7a4e2e1a2b3c")
+	want := "9a8f8e32-1c3b-4e1e-8a3e-7a4e2e1a2b3c"
+	if got != want {
+		t.Fatalf("dashify() = %q, want %q", got, want)
+	}
+}
+
+const hex64 = "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
@@ -0,0 +1,128 @@
+package kubepods
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// PodMeta is the human-readable pod/container identity Resolve recovers for
+// an Info.
+type PodMeta struct {
+	PodName       string
+	PodNamespace  string
+	ContainerName string
+}
+
+// Resolver turns an Info into PodMeta.
+type Resolver interface {
+	Resolve(Info) (PodMeta, error)
+}
+
+// kubeletResolver resolves pods from the kubelet's on-disk pod log
+// directories, which encode namespace and pod name in their path:
+// /var/log/pods/<namespace>_<name>_<uid>/<container-name>/<n>.log. It needs
+// no socket and works even when no CRI runtime is reachable, but can only
+// name ContainerName when the pod has a single container.
+type kubeletResolver struct {
+	logDir string
+}
+
+// NewKubeletResolver returns a Resolver backed by the kubelet's pod log
+// directory (/var/log/pods).
+func NewKubeletResolver() Resolver {
+	return &kubeletResolver{logDir: "/var/log/pods"}
+}
+
+func (r *kubeletResolver) Resolve(info Info) (PodMeta, error) {
+	entries, err := os.ReadDir(r.logDir)
+	if err != nil {
+		return PodMeta{}, err
+	}
+
+	suffix := "_" + info.PodUID
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasSuffix(e.Name(), suffix) {
+			continue
+		}
+		// Dir name is "<namespace>_<name>_<uid>"; namespace never contains
+		// underscores, so split on the first one.
+		parts := strings.SplitN(strings.TrimSuffix(e.Name(), suffix), "_", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		meta := PodMeta{PodNamespace: parts[0], PodName: parts[1]}
+
+		containers, err := os.ReadDir(filepath.Join(r.logDir, e.Name()))
+		if err == nil && len(containers) == 1 {
+			meta.ContainerName = containers[0].Name()
+		}
+		return meta, nil
+	}
+
+	return PodMeta{}, fmt.Errorf("kubepods: no pod log directory found for UID %s", info.PodUID)
+}
+
+// criResolver resolves pods via the Kubernetes CRI gRPC service
+// (ListPodSandbox + ListContainers), which is exact but requires the
+// container runtime's socket to be reachable.
+type criResolver struct {
+	socket string
+}
+
+// NewCRIResolver returns a Resolver backed by a CRI runtime socket, e.g.
+// "/run/containerd/containerd.sock" or "/var/run/crio/crio.sock".
+func NewCRIResolver(socket string) Resolver {
+	return &criResolver{socket: socket}
+}
+
+func (r *criResolver) Resolve(info Info) (PodMeta, error) {
+	conn, err := grpc.Dial("unix://"+r.socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return PodMeta{}, err
+	}
+	defer conn.Close()
+
+	client := criapi.NewRuntimeServiceClient(conn)
+	ctx := context.Background()
+
+	sandboxes, err := client.ListPodSandbox(ctx, &criapi.ListPodSandboxRequest{})
+	if err != nil {
+		return PodMeta{}, err
+	}
+	var sandboxID string
+	var meta PodMeta
+	for _, s := range sandboxes.Items {
+		if s.Metadata.Uid == info.PodUID {
+			sandboxID = s.Id
+			meta.PodName = s.Metadata.Name
+			meta.PodNamespace = s.Metadata.Namespace
+			break
+		}
+	}
+	if sandboxID == "" {
+		return PodMeta{}, fmt.Errorf("cri: no pod sandbox found for UID %s", info.PodUID)
+	}
+
+	if info.ContainerID != "" {
+		containers, err := client.ListContainers(ctx, &criapi.ListContainersRequest{
+			Filter: &criapi.ContainerFilter{PodSandboxId: sandboxID},
+		})
+		if err == nil {
+			for _, c := range containers.Containers {
+				if c.Id == info.ContainerID {
+					meta.ContainerName = c.Metadata.Name
+					break
+				}
+			}
+		}
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,107 @@
+// Package model defines the data structures shared between witr's process
+// scanners and its output renderers.
+package model
+
+import "time"
+
+// Process describes a single running process and everything witr was able
+// to infer about it: its OS-level identity, working context, and the
+// container layer it may be running inside.
+type Process struct {
+	PID            int
+	PPID           int
+	Command        string
+	Cmdline        string
+	StartedAt      time.Time
+	User           string
+	WorkingDir     string
+	GitRepo        string
+	GitBranch      string
+	Container      string
+	Service        string
+	ListeningPorts []int
+	BindAddresses  []string
+	// HostHealth is witr's own verdict on the process, derived from
+	// /proc state and CPU/RAM thresholds: "healthy", "zombie", "stopped",
+	// "high-cpu", or "high-mem". This predates ContainerHealth, which
+	// reflects the container runtime's opinion instead.
+	HostHealth string
+	Forked     string
+	Env        []string
+
+	// ImageName is the image of the container a docker-proxy process
+	// forwards to (e.g. "nginx:1.25"), resolved via the Docker Engine API.
+	// It is set only for docker-proxy processes; see Image for the image
+	// of the container a process itself runs in.
+	ImageName string
+	// ComposeProject is the value of that docker-proxy target container's
+	// "com.docker.compose.project" label, if any.
+	ComposeProject string
+	// ContainerLabels holds the full label set Docker reports for that
+	// docker-proxy target container.
+	ContainerLabels map[string]string
+	// ContainerHealth is the container runtime's own healthcheck verdict,
+	// as opposed to HostHealth which witr derives itself. Only Docker and
+	// Podman containers populate it; a zero value means the runtime has no
+	// opinion (no healthcheck configured, or the runtime doesn't support
+	// one).
+	ContainerHealth ContainerHealth
+
+	// ContainerID is the full container ID extracted from the process's
+	// cgroup path, regardless of which runtime owns it.
+	ContainerID string
+	// Runtime is the container runtime that owns ContainerID: "docker",
+	// "containerd", "cri-o", or "podman". For a process under a kubepods
+	// cgroup it is "kubernetes", and the underlying runtime is not named.
+	Runtime string
+	// Image is the image of the container the process runs in, resolved
+	// via the runtime's socket from the container ID in its cgroup path.
+	Image string
+
+	// PodName, PodNamespace, and PodUID identify the Kubernetes pod a
+	// process belongs to. PodUID is parsed from its kubepods cgroup path;
+	// PodName and PodNamespace are resolved from that UID.
+	PodName      string
+	PodNamespace string
+	PodUID       string
+	// ContainerName is the pod-relative container name (as set in the pod
+	// spec), as opposed to Runtime's opaque ContainerID.
+	ContainerName string
+	// QoSClass is the pod's Kubernetes QoS class: "guaranteed",
+	// "burstable", or "besteffort".
+	QoSClass string
+
+	// Namespaces fingerprints the Linux namespaces a process lives in, so
+	// callers can cluster processes by namespace and attribute rootless
+	// containers to the host user that owns them.
+	Namespaces Namespaces
+}
+
+// Namespaces identifies the Linux namespaces a process belongs to, by the
+// inode number of each /proc/<pid>/ns/* entry.
+type Namespaces struct {
+	UserNS uint64
+	PIDNS  uint64
+	MntNS  uint64
+	NetNS  uint64
+	// Rootless is true when the process's user namespace differs from
+	// PID 1's, i.e. it is not running directly on the host.
+	Rootless bool
+	// HostUID is the host-side UID that owns the process's user namespace:
+	// the outside-id that uid_map maps in-container UID 0 to. It equals
+	// the process's own UID when Rootless is false.
+	HostUID int
+}
+
+// ContainerHealth is a container runtime's own healthcheck verdict for a
+// container, as opposed to the host-derived Process.HostHealth.
+type ContainerHealth struct {
+	// Status is "starting", "healthy", or "unhealthy".
+	Status        string
+	FailingStreak int
+	RestartCount  int
+	// LastProbeAt and LastExitCode describe the most recent healthcheck
+	// probe.
+	LastProbeAt  time.Time
+	LastExitCode int
+}
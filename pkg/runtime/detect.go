@@ -0,0 +1,134 @@
+// Package runtime detects which container runtime, if any, a process
+// belongs to by parsing its cgroup membership, and resolves the resulting
+// container ID to human-readable metadata.
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Names of the runtimes Detect recognizes.
+const (
+	Docker     = "docker"
+	Containerd = "containerd"
+	CRIO       = "cri-o"
+	Podman     = "podman"
+)
+
+// Info identifies the container runtime and container a process belongs to,
+// as determined purely from its cgroup path.
+type Info struct {
+	Runtime     string
+	ContainerID string
+	// Rootless is true when the cgroup path shows a Podman container
+	// running under a user-owned slice (rootless Podman) rather than a
+	// system-wide one. Rootless Docker containers are not flagged.
+	Rootless bool
+}
+
+var patterns = []struct {
+	runtime string
+	re      *regexp.Regexp
+}{
+	// Docker, containerd, and CRI-O are matched under both cgroup drivers:
+	// systemd, which names the scope unit "<prefix>-<id>.scope", and
+	// cgroupfs, which nests the ID in a path component ("/docker/<id>",
+	// "/containerd/<id>", "/crio-<id>"). Podman is matched by its systemd
+	// scope only. cri-containerd-<id> and crio-<id> are checked before the
+	// bare containerd.service / cgroupfs containerd pattern.
+	{Containerd, regexp.MustCompile(`cri-containerd-([0-9a-f]{12,64})\.scope`)},
+	{CRIO, regexp.MustCompile(`crio-([0-9a-f]{12,64})\.scope`)},
+	{CRIO, regexp.MustCompile(`(?:^|/)crio-([0-9a-f]{12,64})(?:/|$)`)},
+	{Docker, regexp.MustCompile(`docker-([0-9a-f]{12,64})\.scope`)},
+	{Docker, regexp.MustCompile(`(?:^|/)docker/([0-9a-f]{12,64})(?:/|$)`)},
+	{Podman, regexp.MustCompile(`libpod-([0-9a-f]{12,64})\.scope`)},
+	{Containerd, regexp.MustCompile(`(?:^|/)containerd/([0-9a-f]{12,64})(?:/|$)`)},
+	// Daemon-wide unit, not a specific container; matched last, and only
+	// when nothing more specific (including the cgroupfs shapes above) hit.
+	{Containerd, regexp.MustCompile(`system\.slice/containerd\.service`)},
+}
+
+var rootlessPodman = regexp.MustCompile(`user\.slice/user-\d+\.slice/user@\d+\.service/user\.slice/libpod-([0-9a-f]{12,64})\.scope`)
+
+// Detect parses /proc/<pid>/cgroup and reports which container runtime, if
+// any, owns the process. It understands both the cgroup v1 per-hierarchy
+// format and the cgroup v2 unified "0::/..." format.
+func Detect(pid int) (Info, error) {
+	paths, err := CgroupPaths(pid)
+	if err != nil {
+		return Info{}, err
+	}
+	for _, path := range paths {
+		if info := ParsePath(path); info.Runtime != "" {
+			return info, nil
+		}
+	}
+	return Info{}, nil
+}
+
+// CgroupPaths reads /proc/<pid>/cgroup and returns the path component of
+// each line (the part after the last colon), which is what identifies the
+// process's place in the cgroup hierarchy under both v1 and v2.
+func CgroupPaths(pid int) ([]string, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		// Both v1 ("<hierarchy-id>:<controllers>:<path>") and v2
+		// ("0::<path>") put the path after the last colon.
+		idx := strings.LastIndex(line, ":")
+		if idx == -1 {
+			continue
+		}
+		paths = append(paths, line[idx+1:])
+	}
+	return paths, nil
+}
+
+// ParsePath identifies the container runtime, if any, that a single cgroup
+// path component belongs to. It returns a zero Info if path matches none of
+// the known runtime shapes.
+func ParsePath(path string) Info {
+	// kubepods paths nest a runtime-scope suffix (e.g.
+	// ".../kubepods-burstable-pod<uid>.slice/cri-containerd-<cid>.scope"),
+	// which would otherwise also match the containerd/CRI-O/Docker patterns
+	// below. Check for kubepods first so pkg/kubepods always gets to parse
+	// the full path for pod/container detail.
+	if strings.Contains(path, "kubepods") {
+		return Info{Runtime: "kubernetes"}
+	}
+	if m := rootlessPodman.FindStringSubmatch(path); m != nil {
+		return Info{Runtime: Podman, ContainerID: m[1], Rootless: true}
+	}
+	for _, p := range patterns {
+		m := p.re.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		info := Info{Runtime: p.runtime}
+		if len(m) > 1 {
+			info.ContainerID = m[1]
+		}
+		return info
+	}
+	// Last resort: a loose substring match, so an unrecognized path shape
+	// for a known runtime still reports that runtime (with no container
+	// ID) instead of nothing at all.
+	switch {
+	case strings.Contains(path, "docker"):
+		return Info{Runtime: Docker}
+	case strings.Contains(path, "containerd"):
+		return Info{Runtime: Containerd}
+	case strings.Contains(path, "crio"):
+		return Info{Runtime: CRIO}
+	case strings.Contains(path, "libpod"):
+		return Info{Runtime: Podman}
+	}
+	return Info{}
+}
@@ -0,0 +1,42 @@
+package runtime
+
+import "testing"
+
+func TestParsePath(t *testing.T) {
+	const id = "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcd"
+
+	cases := []struct {
+		name string
+		path string
+		want Info
+	}{
+		{"docker systemd driver", "/system.slice/docker-" + id + ".scope", Info{Runtime: Docker, ContainerID: id}},
+		{"docker cgroupfs driver", "/docker/" + id, Info{Runtime: Docker, ContainerID: id}},
+		{"containerd cgroupfs driver", "/containerd/" + id, Info{Runtime: Containerd, ContainerID: id}},
+		{"containerd daemon unit", "/system.slice/containerd.service", Info{Runtime: Containerd}},
+		{"cri-containerd scope", "/kubepods.slice/cri-containerd-" + id + ".scope", Info{Runtime: "kubernetes"}},
+		{"crio systemd driver", "/crio-" + id + ".scope", Info{Runtime: CRIO, ContainerID: id}},
+		{"crio cgroupfs driver", "/crio-" + id, Info{Runtime: CRIO, ContainerID: id}},
+		{"podman systemd driver", "/libpod-" + id + ".scope", Info{Runtime: Podman, ContainerID: id}},
+		{
+			"rootless podman",
+			"/user.slice/user-1000.slice/user@1000.service/user.slice/libpod-" + id + ".scope",
+			Info{Runtime: Podman, ContainerID: id, Rootless: true},
+		},
+		{"kubepods takes priority over runtime-scope patterns", "/kubepods.slice/kubepods-pod123.slice/docker-" + id + ".scope", Info{Runtime: "kubernetes"}},
+		{"loose docker fallback", "/some/weird/docker/path", Info{Runtime: Docker}},
+		{"loose containerd fallback", "/some/weird/containerd/path", Info{Runtime: Containerd}},
+		{"loose crio fallback", "/some/weird/crio/path", Info{Runtime: CRIO}},
+		{"loose libpod fallback", "/some/weird/libpod/path", Info{Runtime: Podman}},
+		{"no match", "/user.slice/session-1.scope", Info{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ParsePath(tc.path)
+			if got != tc.want {
+				t.Fatalf("ParsePath(%q) = %+v, want %+v", tc.path, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// podmanResolver resolves container IDs via Podman's libpod REST API, which
+// (unlike containerd/CRI-O) speaks plain HTTP over a Unix socket just like
+// the Docker Engine API.
+type podmanResolver struct {
+	socket string
+}
+
+func (r *podmanResolver) Resolve(info Info) (Container, error) {
+	client := &http.Client{
+		Timeout: 2 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", r.socket)
+			},
+		},
+	}
+
+	resp, err := client.Get(fmt.Sprintf("http://podman/v4.0.0/libpod/containers/%s/json", info.ContainerID))
+	if err != nil {
+		return Container{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Container{}, fmt.Errorf("podman: container %s: unexpected status %d", info.ContainerID, resp.StatusCode)
+	}
+
+	var inspect struct {
+		Name   string
+		Config struct {
+			Image string
+		}
+		RestartCount int
+		State        struct {
+			Healthcheck struct {
+				Status        string
+				FailingStreak int
+				Log           []struct {
+					Start    time.Time
+					ExitCode int
+				}
+			}
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&inspect); err != nil {
+		return Container{}, err
+	}
+
+	health := Health{
+		Status:        inspect.State.Healthcheck.Status,
+		FailingStreak: inspect.State.Healthcheck.FailingStreak,
+	}
+	if n := len(inspect.State.Healthcheck.Log); n > 0 {
+		last := inspect.State.Healthcheck.Log[n-1]
+		health.LastProbeAt = last.Start
+		health.LastExitCode = last.ExitCode
+	}
+
+	return Container{
+		Name:         inspect.Name,
+		Image:        inspect.Config.Image,
+		Health:       health,
+		RestartCount: inspect.RestartCount,
+	}, nil
+}
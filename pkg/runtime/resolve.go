@@ -0,0 +1,155 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// Health is a container's runtime-reported healthcheck state. Only Podman
+// populates it today; containerd and CRI-O have no native healthcheck
+// concept, since Kubernetes implements liveness/readiness probing itself.
+type Health struct {
+	Status        string
+	FailingStreak int
+	LastProbeAt   time.Time
+	LastExitCode  int
+}
+
+// Container holds the human-readable metadata Resolve recovers for a
+// container ID, once Detect has told us which runtime and socket to ask.
+type Container struct {
+	Name         string
+	Image        string
+	Health       Health
+	RestartCount int
+}
+
+// Resolver turns an Info (runtime + container ID) into Container metadata.
+// The concrete implementations below each speak to one runtime's socket;
+// Resolve dispatches to whichever one applies.
+type Resolver interface {
+	Resolve(Info) (Container, error)
+}
+
+// NewResolver returns a Resolver that dials whichever of the well-known
+// runtime sockets are present on the host.
+func NewResolver() Resolver {
+	return dispatchResolver{
+		containerd: &containerdResolver{socket: "/run/containerd/containerd.sock"},
+		crio:       &crioResolver{socket: "/var/run/crio/crio.sock"},
+		podman:     &podmanResolver{socket: podmanSocket()},
+	}
+}
+
+func podmanSocket() string {
+	if uid := os.Getuid(); uid != 0 {
+		if p := fmt.Sprintf("/run/user/%d/podman/podman.sock", uid); fileExists(p) {
+			return p
+		}
+	}
+	return "/run/podman/podman.sock"
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+type dispatchResolver struct {
+	containerd *containerdResolver
+	crio       *crioResolver
+	podman     *podmanResolver
+}
+
+func (d dispatchResolver) Resolve(info Info) (Container, error) {
+	switch info.Runtime {
+	case Containerd:
+		return d.containerd.Resolve(info)
+	case CRIO:
+		return d.crio.Resolve(info)
+	case Podman:
+		return d.podman.Resolve(info)
+	case Docker:
+		return Container{}, fmt.Errorf("runtime: docker containers are resolved via pkg/docker, not pkg/runtime")
+	default:
+		return Container{}, fmt.Errorf("runtime: no resolver for runtime %q", info.Runtime)
+	}
+}
+
+// containerdResolver resolves container IDs via containerd's native gRPC
+// API, looking first in the "default" namespace that Docker-less containerd
+// workloads (e.g. nerdctl) run in, then in "k8s.io", where Kubernetes via
+// cri-containerd runs them.
+type containerdResolver struct {
+	socket string
+}
+
+func (r *containerdResolver) Resolve(info Info) (Container, error) {
+	client, err := containerd.New(r.socket)
+	if err != nil {
+		return Container{}, err
+	}
+	defer client.Close()
+
+	ctx := namespaces.WithNamespace(context.Background(), "default")
+	c, err := client.LoadContainer(ctx, info.ContainerID)
+	if err != nil {
+		// k8s workloads run in the "k8s.io" namespace instead.
+		ctx = namespaces.WithNamespace(context.Background(), "k8s.io")
+		c, err = client.LoadContainer(ctx, info.ContainerID)
+		if err != nil {
+			return Container{}, err
+		}
+	}
+
+	image, err := c.Image(ctx)
+	imageName := ""
+	if err == nil {
+		imageName = image.Name()
+	}
+
+	// containerd has no first-class "container name"; nerdctl is the one
+	// common client that assigns one, via a label. Leave Name empty rather
+	// than fall back to the opaque container ID, which isn't a name.
+	labels, err := c.Labels(ctx)
+	name := ""
+	if err == nil {
+		name = labels["nerdctl/name"]
+	}
+	return Container{Name: name, Image: imageName}, nil
+}
+
+// crioResolver resolves container IDs via the Kubernetes CRI gRPC service,
+// which CRI-O implements natively.
+type crioResolver struct {
+	socket string
+}
+
+func (r *crioResolver) Resolve(info Info) (Container, error) {
+	conn, err := grpc.Dial("unix://"+r.socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return Container{}, err
+	}
+	defer conn.Close()
+
+	client := criapi.NewRuntimeServiceClient(conn)
+	status, err := client.ContainerStatus(context.Background(), &criapi.ContainerStatusRequest{
+		ContainerId: info.ContainerID,
+	})
+	if err != nil {
+		return Container{}, err
+	}
+
+	return Container{
+		Name:  status.Status.Metadata.Name,
+		Image: status.Status.Image.Image,
+	}, nil
+}